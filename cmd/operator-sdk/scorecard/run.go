@@ -0,0 +1,182 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	scplugins "github.com/operator-framework/operator-sdk/internal/scorecard/plugins"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// runOpts holds the `scorecard run` flags, which map directly onto
+// scplugins.BasicAndOLMPluginConfig.
+type runOpts struct {
+	pluginType string
+
+	kubeconfig string
+	namespace  string
+
+	crManifest         []string
+	csvManifest        string
+	bundleImage        string
+	crdsDir            string
+	globalManifest     string
+	namespacedManifest string
+
+	proxyImage      string
+	proxyPullPolicy string
+
+	initTimeout int
+	selector    string
+
+	olmDeployed bool
+	parallelCRs bool
+
+	testsFile string
+
+	outputFormats []string
+	outputDir     string
+}
+
+// NewRunCmd returns the `operator-sdk scorecard run` command: a one-shot
+// invocation of scplugins.RunInternalPlugin that rebuilds the kubeconfig/
+// runtime client for this run only, unlike `scorecard serve` (see
+// serve.go), which keeps them warm across many runs.
+func NewRunCmd() *cobra.Command {
+	opts := runOpts{}
+	cmd := &cobra.Command{
+		Use:   "run",
+		Short: "Run a scorecard test suite once and print its results",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRun(opts)
+		},
+	}
+	addRunFlags(cmd.Flags(), &opts)
+	return cmd
+}
+
+func addRunFlags(flagSet *pflag.FlagSet, opts *runOpts) {
+	flagSet.StringVar(&opts.pluginType, "plugin", "basic", "scorecard plugin to run: basic or olm")
+
+	flagSet.StringVar(&opts.kubeconfig, "kubeconfig", "", "path to kubeconfig (defaults to in-cluster or ~/.kube/config)")
+	flagSet.StringVar(&opts.namespace, "namespace", "", "namespace to run in (defaults to the kubeconfig's current namespace)")
+
+	flagSet.StringSliceVar(&opts.crManifest, "cr-manifest", nil,
+		"path to a CR manifest to test (repeatable); defaults to every CR in the CSV's alm-examples under --olm-deployed")
+	flagSet.StringVar(&opts.csvManifest, "csv-manifest", "", "path to the operator's ClusterServiceVersion")
+	flagSet.StringVar(&opts.bundleImage, "bundle-image", "",
+		"operator bundle image to extract --csv-manifest/--crds-dir from, instead of reading them from disk")
+	flagSet.StringVar(&opts.crdsDir, "crds-dir", "", "directory of CRD manifests to combine into a global manifest")
+	flagSet.StringVar(&opts.globalManifest, "global-manifest", "", "path to a combined CRD manifest (generated from --crds-dir if unset)")
+	flagSet.StringVar(&opts.namespacedManifest, "namespaced-manifest", "",
+		"path to a combined service account/role/role binding/operator manifest")
+
+	flagSet.StringVar(&opts.proxyImage, "proxy-image", "quay.io/operator-framework/scorecard-proxy",
+		"image for the scorecard-proxy container")
+	flagSet.StringVar(&opts.proxyPullPolicy, "proxy-pull-policy", "Always", "pull policy for the scorecard-proxy container")
+
+	flagSet.IntVar(&opts.initTimeout, "init-timeout", 10, "seconds to wait for a CR's status to be populated")
+	flagSet.StringVar(&opts.selector, "selector", "", "label selector restricting which tests run")
+
+	flagSet.BoolVar(&opts.olmDeployed, "olm-deployed", false, "the operator under test was deployed by OLM from --csv-manifest")
+	flagSet.BoolVar(&opts.parallelCRs, "parallel-crs", false, "test every --cr-manifest entry concurrently instead of one at a time")
+
+	flagSet.StringVar(&opts.testsFile, "tests-file", "",
+		"path to a tests.yaml of user-contributed declarative tests to run alongside --plugin's built-in suite")
+
+	flagSet.StringSliceVar(&opts.outputFormats, "output-format", nil,
+		"additional format(s) to write results in, alongside the JSON printed to stdout (repeatable): json, junit, sarif")
+	flagSet.StringVar(&opts.outputDir, "output-dir", "", "directory to write --output-format results into")
+}
+
+func runRun(opts runOpts) error {
+	pluginType, err := parsePluginType(opts.pluginType)
+	if err != nil {
+		return err
+	}
+
+	selector := labels.Everything()
+	if opts.selector != "" {
+		selector, err = labels.Parse(opts.selector)
+		if err != nil {
+			return fmt.Errorf("failed to parse --selector %q: %v", opts.selector, err)
+		}
+	}
+
+	if len(opts.outputFormats) > 0 && opts.outputDir == "" {
+		return fmt.Errorf("--output-dir is required when --output-format is set")
+	}
+	for _, format := range opts.outputFormats {
+		switch scplugins.OutputFormat(format) {
+		case scplugins.OutputFormatJSON, scplugins.OutputFormatJUnit, scplugins.OutputFormatSARIF:
+		default:
+			return fmt.Errorf("unknown --output-format %q: must be one of json, junit, sarif", format)
+		}
+	}
+
+	config := scplugins.BasicAndOLMPluginConfig{
+		Kubeconfig: opts.kubeconfig,
+		Namespace:  opts.namespace,
+
+		CRManifest:         opts.crManifest,
+		CSVManifest:        opts.csvManifest,
+		BundleImage:        opts.bundleImage,
+		CRDsDir:            opts.crdsDir,
+		GlobalManifest:     opts.globalManifest,
+		NamespacedManifest: opts.namespacedManifest,
+
+		ProxyImage:      opts.proxyImage,
+		ProxyPullPolicy: v1.PullPolicy(opts.proxyPullPolicy),
+
+		InitTimeout: opts.initTimeout,
+		Selector:    selector,
+
+		OLMDeployed: opts.olmDeployed,
+		ParallelCRs: opts.parallelCRs,
+
+		TestsFile: opts.testsFile,
+
+		OutputFormats: opts.outputFormats,
+		OutputDir:     opts.outputDir,
+	}
+
+	output, err := scplugins.RunInternalPlugin(pluginType, config, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(output)
+}
+
+func parsePluginType(s string) (scplugins.PluginType, error) {
+	switch s {
+	case "basic", "":
+		return scplugins.BasicOperator, nil
+	case "olm":
+		return scplugins.OLMIntegration, nil
+	default:
+		return 0, fmt.Errorf("unknown scorecard plugin type: %s", s)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scorecard
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	scplugins "github.com/operator-framework/operator-sdk/internal/scorecard/plugins"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// serveOpts holds the `scorecard serve` flags.
+type serveOpts struct {
+	kubeconfig string
+	grpcAddr   string
+	restAddr   string
+}
+
+// NewServeCmd returns the `operator-sdk scorecard serve` command, which
+// starts a long-running scplugins.Server (see internal/scorecard/plugins)
+// and exposes it over both gRPC and REST, so CI dashboards and IDE
+// integrations can list/run suites and stream results without paying the
+// kubeconfig/restmapper setup cost that the one-shot `scorecard run` pays
+// per invocation.
+func NewServeCmd() *cobra.Command {
+	opts := serveOpts{}
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the scorecard as a long-running gRPC/REST service",
+		Long: `serve starts a scorecard server that resolves the kubeconfig and runtime
+client once and keeps them warm across every ListSuites/RunSuite/StreamResults
+call it receives, instead of the one-shot 'operator-sdk scorecard run' behavior
+of rebuilding them on every invocation. It listens for gRPC on --grpc-addr and
+for an equivalent REST API on --rest-addr.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(opts)
+		},
+	}
+	addServeFlags(cmd.Flags(), &opts)
+	return cmd
+}
+
+func addServeFlags(flagSet *pflag.FlagSet, opts *serveOpts) {
+	flagSet.StringVar(&opts.kubeconfig, "kubeconfig", "", "path to kubeconfig (defaults to in-cluster or ~/.kube/config)")
+	flagSet.StringVar(&opts.grpcAddr, "grpc-addr", ":8181", "address for the gRPC scorecard service to listen on")
+	flagSet.StringVar(&opts.restAddr, "rest-addr", ":8182", "address for the REST scorecard service to listen on")
+}
+
+func runServe(opts serveOpts) error {
+	s, err := scplugins.NewServer(opts.kubeconfig)
+	if err != nil {
+		return fmt.Errorf("failed to start scorecard server: %v", err)
+	}
+
+	grpcLis, err := net.Listen("tcp", opts.grpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", opts.grpcAddr, err)
+	}
+	grpcServer := scplugins.NewGRPCServer(s, os.Stderr)
+
+	errCh := make(chan error, 2)
+	go func() {
+		errCh <- grpcServer.Serve(grpcLis)
+	}()
+
+	restServer := &http.Server{
+		Addr:    opts.restAddr,
+		Handler: scplugins.NewRESTHandler(s, os.Stderr),
+	}
+	go func() {
+		errCh <- restServer.ListenAndServe()
+	}()
+
+	fmt.Fprintf(os.Stderr, "scorecard serve: gRPC listening on %s, REST listening on %s\n",
+		opts.grpcAddr, opts.restAddr)
+	return <-errCh
+}
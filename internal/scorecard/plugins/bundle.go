@@ -0,0 +1,222 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/google/go-containerregistry/pkg/crane"
+)
+
+// bundleAnnotations mirrors the relevant fields of the annotations.yaml
+// file found in an operator bundle image's metadata/ directory, which
+// points at the manifests and metadata directories packed into the bundle.
+type bundleAnnotations struct {
+	Annotations struct {
+		ManifestsDir string `json:"operators.operatorframework.io.bundle.manifests.v1,omitempty"`
+		MetadataDir  string `json:"operators.operatorframework.io.bundle.metadata.v1,omitempty"`
+	} `json:"annotations"`
+}
+
+// extractBundleImage pulls the operator bundle image ref (e.g.
+// quay.io/foo/bar-bundle:vX) and unpacks it to a temporary directory,
+// returning the path to the CSV manifest found under manifests/ and a
+// directory holding copies of just the bundle's CRD manifests (manifests/
+// also contains the CSV and possibly other resources, which callers that
+// treat CRDsDir as CRD-only, like yamlutil.GenerateCombinedGlobalManifest,
+// must not see). The caller is responsible for removing the returned
+// crdsDir's parent directory, the extraction root, once it's done with the
+// extracted files.
+func extractBundleImage(ref string, logger *runLogger) (csvManifest string, crdsDir string, err error) {
+	tmpDir, err := ioutil.TempDir("", "operator-sdk-bundle")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create temp dir to extract bundle image: %v", err)
+	}
+	succeeded := false
+	defer func() {
+		if !succeeded {
+			if rmErr := os.RemoveAll(tmpDir); rmErr != nil {
+				logger.Errorf("Could not delete bundle image extraction directory: (%v)", rmErr)
+			}
+		}
+	}()
+
+	img, err := crane.Pull(ref)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to pull bundle image %s: %v", ref, err)
+	}
+
+	tarPath := filepath.Join(tmpDir, "bundle.tar")
+	tarFile, err := os.Create(tarPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create bundle tarball: %v", err)
+	}
+	if err := crane.Export(img, tarFile); err != nil {
+		tarFile.Close()
+		return "", "", fmt.Errorf("failed to export bundle image %s: %v", ref, err)
+	}
+	tarFile.Close()
+
+	if err := untar(tarPath, tmpDir); err != nil {
+		return "", "", fmt.Errorf("failed to unpack bundle image %s: %v", ref, err)
+	}
+
+	annotations, err := readBundleAnnotations(tmpDir)
+	if err != nil {
+		return "", "", err
+	}
+
+	manifestsDir := annotations.Annotations.ManifestsDir
+	if manifestsDir == "" {
+		manifestsDir = "manifests"
+	}
+	manifestsDir = filepath.Join(tmpDir, manifestsDir)
+
+	csvManifest, err = findCSVManifest(manifestsDir)
+	if err != nil {
+		return "", "", err
+	}
+
+	crdsDir, err = extractCRDManifests(manifestsDir)
+	if err != nil {
+		return "", "", err
+	}
+
+	succeeded = true
+	return csvManifest, crdsDir, nil
+}
+
+// extractCRDManifests copies every manifest in manifestsDir whose kind is
+// CustomResourceDefinition into a fresh "crds" directory alongside it, so a
+// bundle-image run's CRDsDir holds nothing but CRDs - the same as a
+// deploy/crds tree - rather than manifestsDir itself, which also contains
+// the CSV and possibly other bundle resources.
+func extractCRDManifests(manifestsDir string) (string, error) {
+	files, err := ioutil.ReadDir(manifestsDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read bundle manifests dir %s: %v", manifestsDir, err)
+	}
+
+	crdsDir := filepath.Join(filepath.Dir(manifestsDir), "crds")
+	if err := os.MkdirAll(crdsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create bundle crds dir: %v", err)
+	}
+
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		path := filepath.Join(manifestsDir, f.Name())
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read bundle manifest %s: %v", path, err)
+		}
+
+		meta := struct {
+			Kind string `json:"kind"`
+		}{}
+		if err := yaml.Unmarshal(b, &meta); err != nil || meta.Kind != "CustomResourceDefinition" {
+			continue
+		}
+
+		if err := ioutil.WriteFile(filepath.Join(crdsDir, f.Name()), b, 0644); err != nil {
+			return "", fmt.Errorf("failed to copy CRD manifest %s: %v", f.Name(), err)
+		}
+	}
+
+	return crdsDir, nil
+}
+
+// readBundleAnnotations reads and parses metadata/annotations.yaml from an
+// unpacked bundle image.
+func readBundleAnnotations(bundleDir string) (*bundleAnnotations, error) {
+	metadataDir := filepath.Join(bundleDir, "metadata")
+	b, err := ioutil.ReadFile(filepath.Join(metadataDir, "annotations.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle metadata/annotations.yaml: %v", err)
+	}
+	annotations := &bundleAnnotations{}
+	if err := yaml.Unmarshal(b, annotations); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle metadata/annotations.yaml: %v", err)
+	}
+	return annotations, nil
+}
+
+// findCSVManifest returns the path to the first ClusterServiceVersion
+// manifest found directly under dir.
+func findCSVManifest(dir string) (string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read bundle manifests dir %s: %v", dir, err)
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), "clusterserviceversion.yaml") {
+			continue
+		}
+		return filepath.Join(dir, f.Name()), nil
+	}
+	return "", fmt.Errorf("no ClusterServiceVersion manifest found in bundle manifests dir %s", dir)
+}
+
+// untar extracts the uncompressed tarball at tarPath into destDir.
+func untar(tarPath, destDir string) error {
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(destDir, hdr.Name)
+		if !strings.HasPrefix(target, filepath.Clean(destDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("bundle image tarball entry %q escapes extraction directory", hdr.Name)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode))
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			out.Close()
+		}
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// BasicAndOLMPluginConfig configures a run of the basic and/or OLM
+// integration scorecard plugins, whether through the one-shot
+// RunInternalPlugin/ListInternalPlugin entrypoints or a long-running
+// Server. Fields are added here as the scorecard gains the feature that
+// needs them; see the cobra flags in cmd/operator-sdk/scorecard for how
+// each is set from the CLI.
+type BasicAndOLMPluginConfig struct {
+	// Kubeconfig is the path to the kubeconfig used to reach the cluster.
+	// Empty resolves to the in-cluster config or ~/.kube/config.
+	Kubeconfig string
+	// Namespace the operator, its CRs and any resources this run creates
+	// live in. Empty resolves to the kubeconfig's current context namespace.
+	Namespace string
+
+	// CRManifest is the set of CR manifests to run the test suite(s)
+	// against. Under --olm-deployed, empty resolves to one manifest per CR
+	// in the CSV's metadata.annotations['alm-examples'].
+	CRManifest []string
+	// CSVManifest is the path to the operator's ClusterServiceVersion.
+	CSVManifest string
+	// BundleImage is an operator bundle image reference (e.g.
+	// quay.io/foo/bar-bundle:vX) to extract CSVManifest/CRDsDir from instead
+	// of reading them off disk directly.
+	BundleImage string
+	// CRDsDir is the directory of CRD manifests to combine into
+	// GlobalManifest when GlobalManifest isn't set directly.
+	CRDsDir string
+	// GlobalManifest is the path to the combined CRD manifest scorecard
+	// applies before NamespacedManifest/CRManifest. Generated from CRDsDir
+	// if empty.
+	GlobalManifest string
+	// NamespacedManifest is the path to the combined
+	// service_account/role/role_binding/operator manifest scorecard
+	// applies. Generated from scaffold.DeployDir if empty.
+	NamespacedManifest string
+
+	// ProxyImage and ProxyPullPolicy configure the scorecard-proxy sidecar
+	// injected into the operator's Deployment so the built-in test suites
+	// can observe its calls to the API server.
+	ProxyImage      string
+	ProxyPullPolicy v1.PullPolicy
+
+	// InitTimeout is how long, in seconds, to wait for a CR's status
+	// subresource to be populated before failing its test suite(s).
+	InitTimeout int
+	// Selector restricts the tests that run to those whose labels match it.
+	Selector labels.Selector
+
+	// Bundle indicates CSVManifest/CRDsDir came from a bundle image/
+	// manifests tree rather than a deploy/ tree, which some of the OLM
+	// integration tests treat differently.
+	Bundle bool
+	// OLMDeployed indicates the operator was already deployed by OLM from
+	// CSVManifest, so scorecard should discover the existing deployment/
+	// proxy pod instead of creating GlobalManifest/NamespacedManifest
+	// itself.
+	OLMDeployed bool
+
+	// ParallelCRs runs the test suite(s) against every entry in CRManifest
+	// concurrently instead of one at a time.
+	ParallelCRs bool
+
+	// TestsFile is the path to a tests.yaml declaring user-contributed
+	// declarative tests (see NewDeclarativeTestSuite). Empty skips
+	// declarative testing; only the pluginType's built-in suite(s) run.
+	TestsFile string
+
+	// OutputFormats is the set of additional encodings (see writeOutputs)
+	// to write the run's results in, alongside the ScorecardOutput JSON
+	// RunInternalPlugin always returns. Empty writes nothing extra.
+	OutputFormats []string
+	// OutputDir is the directory writeOutputs writes OutputFormats into.
+	// Required if OutputFormats is non-empty; otherwise unused.
+	OutputDir string
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDeclarativeTestAssertionsHold(t *testing.T) {
+	cases := []struct {
+		name string
+		def  declarativeTestDef
+		obj  *unstructured.Unstructured
+		want bool
+	}{
+		{
+			name: "no assertions declared always holds",
+			def:  declarativeTestDef{},
+			obj:  &unstructured.Unstructured{Object: map[string]interface{}{}},
+			want: true,
+		},
+		{
+			name: "no status and assertions declared does not hold",
+			def:  declarativeTestDef{StatusFields: map[string]interface{}{"phase": "Running"}},
+			obj:  &unstructured.Unstructured{Object: map[string]interface{}{}},
+			want: false,
+		},
+		{
+			name: "matching status field holds",
+			def:  declarativeTestDef{StatusFields: map[string]interface{}{"phase": "Running"}},
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{"phase": "Running"},
+			}},
+			want: true,
+		},
+		{
+			name: "mismatched status field does not hold",
+			def:  declarativeTestDef{StatusFields: map[string]interface{}{"phase": "Running"}},
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{"phase": "Pending"},
+			}},
+			want: false,
+		},
+		{
+			name: "matching condition holds",
+			def: declarativeTestDef{Conditions: []declarativeCondition{
+				{Type: "Ready", Status: "True"},
+			}},
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "True"},
+					},
+				},
+			}},
+			want: true,
+		},
+		{
+			name: "missing condition does not hold",
+			def: declarativeTestDef{Conditions: []declarativeCondition{
+				{Type: "Ready", Status: "True"},
+			}},
+			obj: &unstructured.Unstructured{Object: map[string]interface{}{
+				"status": map[string]interface{}{
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "False"},
+					},
+				},
+			}},
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			test := &declarativeTest{def: tc.def}
+			if got := test.assertionsHold(tc.obj); got != tc.want {
+				t.Errorf("assertionsHold() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	schelpers "github.com/operator-framework/operator-sdk/internal/scorecard/helpers"
+	scapiv1alpha2 "github.com/operator-framework/operator-sdk/pkg/apis/scorecard/v1alpha2"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// The types and service descriptor below correspond to
+// proto/scorecard.proto. They're hand-written rather than protoc-generated
+// because every field the .proto defines is itself already JSON (config,
+// ScorecardOutput, TestResult are all config.go/scapiv1alpha2/schelpers Go
+// structs marshaled to JSON), so the RPC envelope is encoded with jsonCodec below
+// instead of the binary protobuf wire format; there's nothing for protoc
+// to generate that this file doesn't already say directly.
+
+// RunRequest carries a JSON-encoded BasicAndOLMPluginConfig plus the
+// plugin type to run it against. See proto/scorecard.proto.
+type RunRequest struct {
+	PluginType string `json:"plugin_type"`
+	ConfigJSON []byte `json:"config_json"`
+}
+
+// ScorecardOutput is the JSON-encoded scapiv1alpha2.ScorecardOutput
+// returned by ListSuites/RunSuite. See proto/scorecard.proto.
+type ScorecardOutput struct {
+	OutputJSON []byte `json:"output_json"`
+}
+
+// TestResult is the JSON-encoded schelpers.TestSuite of a single CR,
+// emitted by StreamResults once every test of that CR's suite has run;
+// individual tests are not sent one by one. See proto/scorecard.proto.
+type TestResult struct {
+	ResultJSON []byte `json:"result_json"`
+}
+
+// jsonCodec implements encoding.Codec, marshaling RunRequest/
+// ScorecardOutput/TestResult (and any other message on this service) as
+// JSON instead of binary protobuf, consistent with their JSON-payload
+// design above.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+func (jsonCodec) Name() string                               { return "json" }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// ScorecardServer is the server API for the Scorecard service defined in
+// proto/scorecard.proto.
+type ScorecardServer interface {
+	ListSuites(context.Context, *RunRequest) (*ScorecardOutput, error)
+	RunSuite(context.Context, *RunRequest) (*ScorecardOutput, error)
+	StreamResults(*RunRequest, Scorecard_StreamResultsServer) error
+}
+
+// Scorecard_StreamResultsServer is the server-side stream for the
+// StreamResults RPC. //nolint:golint
+type Scorecard_StreamResultsServer interface {
+	Send(*TestResult) error
+	grpc.ServerStream
+}
+
+type scorecardStreamResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *scorecardStreamResultsServer) Send(m *TestResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// RegisterScorecardServer registers srv as the implementation of the
+// Scorecard service on s.
+func RegisterScorecardServer(s *grpc.Server, srv ScorecardServer) {
+	s.RegisterService(&scorecardServiceDesc, srv)
+}
+
+var scorecardServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scorecard.Scorecard",
+	HandlerType: (*ScorecardServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListSuites",
+			Handler:    scorecardListSuitesHandler,
+		},
+		{
+			MethodName: "RunSuite",
+			Handler:    scorecardRunSuiteHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamResults",
+			Handler:       scorecardStreamResultsHandler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/scorecard.proto",
+}
+
+func scorecardListSuitesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScorecardServer).ListSuites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/scorecard.Scorecard/ListSuites"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScorecardServer).ListSuites(ctx, req.(*RunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func scorecardRunSuiteHandler(srv interface{}, ctx context.Context, dec func(interface{}) error,
+	interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScorecardServer).RunSuite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/scorecard.Scorecard/RunSuite"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScorecardServer).RunSuite(ctx, req.(*RunRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func scorecardStreamResultsHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ScorecardServer).StreamResults(m, &scorecardStreamResultsServer{stream})
+}
+
+// grpcServer adapts the in-process Server (see server.go) to the
+// ScorecardServer RPC surface, translating between its Go-native API
+// (PluginType, BasicAndOLMPluginConfig) and the wire-level JSON payloads
+// RunRequest/ScorecardOutput/TestResult carry.
+type grpcServer struct {
+	*Server
+	logFile io.Writer
+}
+
+// NewGRPCServer returns a *grpc.Server with the Scorecard service backed
+// by s, logging run output to logFile. It uses the JSON codec registered
+// in init above rather than binary protobuf. logFile is shared by every
+// RunSuite/StreamResults call this server handles, so it's wrapped in a
+// syncWriter (see server.go) to keep concurrent calls from racing on it.
+func NewGRPCServer(s *Server, logFile io.Writer) *grpc.Server {
+	gs := grpc.NewServer()
+	RegisterScorecardServer(gs, &grpcServer{Server: s, logFile: &syncWriter{w: logFile}})
+	return gs
+}
+
+func (g *grpcServer) ListSuites(ctx context.Context, req *RunRequest) (*ScorecardOutput, error) {
+	pluginType, config, err := decodeRunRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	output, err := g.Server.ListSuites(pluginType, config)
+	if err != nil {
+		return nil, err
+	}
+	return encodeScorecardOutput(output)
+}
+
+func (g *grpcServer) RunSuite(ctx context.Context, req *RunRequest) (*ScorecardOutput, error) {
+	pluginType, config, err := decodeRunRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	output, err := g.Server.RunSuite(pluginType, config, g.logFile)
+	if err != nil {
+		return nil, err
+	}
+	return encodeScorecardOutput(output)
+}
+
+func (g *grpcServer) StreamResults(req *RunRequest, stream Scorecard_StreamResultsServer) error {
+	pluginType, config, err := decodeRunRequest(req)
+	if err != nil {
+		return err
+	}
+
+	results, errs := g.Server.StreamResults(pluginType, config, g.logFile)
+	return DrainResults(results, errs,
+		func(suite schelpers.TestSuite) error {
+			resultJSON, err := json.Marshal(suite)
+			if err != nil {
+				return err
+			}
+			return stream.Send(&TestResult{ResultJSON: resultJSON})
+		},
+		func(err error) error { return err },
+	)
+}
+
+// decodeRunRequest parses req's wire-level plugin type and JSON config
+// into the Go-native types the rest of this package works with.
+func decodeRunRequest(req *RunRequest) (PluginType, BasicAndOLMPluginConfig, error) {
+	var config BasicAndOLMPluginConfig
+	if err := json.Unmarshal(req.ConfigJSON, &config); err != nil {
+		return 0, config, fmt.Errorf("failed to parse scorecard plugin config: %v", err)
+	}
+	switch req.PluginType {
+	case "basic", "":
+		return BasicOperator, config, nil
+	case "olm":
+		return OLMIntegration, config, nil
+	default:
+		return 0, config, fmt.Errorf("unknown scorecard plugin type: %s", req.PluginType)
+	}
+}
+
+func encodeScorecardOutput(output scapiv1alpha2.ScorecardOutput) (*ScorecardOutput, error) {
+	b, err := json.Marshal(output)
+	if err != nil {
+		return nil, err
+	}
+	return &ScorecardOutput{OutputJSON: b}, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeRunRequest(t *testing.T) {
+	cases := []struct {
+		name       string
+		pluginType string
+		wantPlugin PluginType
+		wantErr    bool
+	}{
+		{name: "empty plugin type defaults to basic", pluginType: "", wantPlugin: BasicOperator},
+		{name: "basic", pluginType: "basic", wantPlugin: BasicOperator},
+		{name: "olm", pluginType: "olm", wantPlugin: OLMIntegration},
+		{name: "unknown plugin type errors", pluginType: "bogus", wantErr: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			wantConfig := BasicAndOLMPluginConfig{
+				Namespace:   "my-namespace",
+				CRManifest:  []string{"deploy/cr.yaml"},
+				ParallelCRs: true,
+			}
+			configJSON, err := json.Marshal(wantConfig)
+			if err != nil {
+				t.Fatalf("failed to marshal config: %v", err)
+			}
+
+			gotPlugin, gotConfig, err := decodeRunRequest(&RunRequest{
+				PluginType: c.pluginType,
+				ConfigJSON: configJSON,
+			})
+			if (err != nil) != c.wantErr {
+				t.Fatalf("decodeRunRequest() error = %v, wantErr %v", err, c.wantErr)
+			}
+			if c.wantErr {
+				return
+			}
+			if gotPlugin != c.wantPlugin {
+				t.Errorf("decodeRunRequest() plugin type = %v, want %v", gotPlugin, c.wantPlugin)
+			}
+			if gotConfig.Namespace != wantConfig.Namespace || gotConfig.ParallelCRs != wantConfig.ParallelCRs ||
+				len(gotConfig.CRManifest) != 1 || gotConfig.CRManifest[0] != wantConfig.CRManifest[0] {
+				t.Errorf("decodeRunRequest() config = %+v, want %+v", gotConfig, wantConfig)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	scapiv1alpha2 "github.com/operator-framework/operator-sdk/pkg/apis/scorecard/v1alpha2"
+)
+
+// junitTestSuites is the top-level JUnit XML document, consumable by
+// Jenkins/GitHub Actions test reporters.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// junitEncoder maps a ScorecardOutput's suites/tests onto JUnit XML
+// testsuites/testsuite/testcase elements.
+type junitEncoder struct{}
+
+func (junitEncoder) Encode(w io.Writer, output scapiv1alpha2.ScorecardOutput) error {
+	doc := junitTestSuites{}
+	for _, suiteRes := range output.Results {
+		suite := junitTestSuite{Name: suiteRes.Name}
+		for _, t := range suiteRes.Tests {
+			tc := junitTestCase{Name: t.Name, SystemOut: t.Log}
+			suite.Tests++
+			switch t.State {
+			case scapiv1alpha2.FailState:
+				suite.Failures++
+				tc.Failure = &junitFailure{Message: "test failed", Text: strings.Join(t.Errors, "\n")}
+			case scapiv1alpha2.ErrorState:
+				suite.Errors++
+				tc.Failure = &junitFailure{Message: "test errored", Text: strings.Join(t.Errors, "\n")}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		doc.Suites = append(doc.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
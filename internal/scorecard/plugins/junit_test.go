@@ -0,0 +1,82 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	scapiv1alpha2 "github.com/operator-framework/operator-sdk/pkg/apis/scorecard/v1alpha2"
+)
+
+func TestJUnitEncoderEncode(t *testing.T) {
+	cases := []struct {
+		name           string
+		output         scapiv1alpha2.ScorecardOutput
+		wantSuiteAttrs string
+		wantContains   []string
+	}{
+		{
+			name: "all tests pass",
+			output: scapiv1alpha2.ScorecardOutput{
+				Results: []scapiv1alpha2.ScorecardSuiteResult{
+					{
+						Name: "basic",
+						Tests: []scapiv1alpha2.ScorecardTestResult{
+							{Name: "check-spec", State: scapiv1alpha2.PassState},
+						},
+					},
+				},
+			},
+			wantSuiteAttrs: `name="basic" tests="1" failures="0" errors="0"`,
+		},
+		{
+			name: "mix of fail and error",
+			output: scapiv1alpha2.ScorecardOutput{
+				Results: []scapiv1alpha2.ScorecardSuiteResult{
+					{
+						Name: "basic",
+						Tests: []scapiv1alpha2.ScorecardTestResult{
+							{Name: "check-spec", State: scapiv1alpha2.FailState, Errors: []string{"spec invalid"}},
+							{Name: "check-status", State: scapiv1alpha2.ErrorState, Errors: []string{"timed out"}},
+						},
+					},
+				},
+			},
+			wantSuiteAttrs: `name="basic" tests="2" failures="1" errors="1"`,
+			wantContains:   []string{"spec invalid", "timed out"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := (junitEncoder{}).Encode(&buf, tc.output); err != nil {
+				t.Fatalf("Encode returned error: %v", err)
+			}
+
+			got := buf.String()
+			if !strings.Contains(got, tc.wantSuiteAttrs) {
+				t.Errorf("expected output to contain %q, got:\n%s", tc.wantSuiteAttrs, got)
+			}
+			for _, want := range tc.wantContains {
+				if !strings.Contains(got, want) {
+					t.Errorf("expected output to contain %q, got:\n%s", want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	scapiv1alpha2 "github.com/operator-framework/operator-sdk/pkg/apis/scorecard/v1alpha2"
+)
+
+// OutputFormat names a format RunInternalPlugin can additionally encode its
+// ScorecardOutput as, so CI pipelines can consume scorecard results with
+// their existing tooling instead of parsing the custom JSON schema.
+type OutputFormat string
+
+const (
+	OutputFormatJSON  OutputFormat = "json"
+	OutputFormatJUnit OutputFormat = "junit"
+	OutputFormatSARIF OutputFormat = "sarif"
+)
+
+// resultEncoder writes output in a particular OutputFormat.
+type resultEncoder interface {
+	Encode(w io.Writer, output scapiv1alpha2.ScorecardOutput) error
+}
+
+// encoderFor returns the resultEncoder for format. csvManifest is used by
+// the SARIF encoder to point findings at the CSV that produced them.
+func encoderFor(format OutputFormat, csvManifest string) (resultEncoder, error) {
+	switch format {
+	case OutputFormatJSON, "":
+		return jsonEncoder{}, nil
+	case OutputFormatJUnit:
+		return junitEncoder{}, nil
+	case OutputFormatSARIF:
+		return sarifEncoder{csvManifest: csvManifest}, nil
+	default:
+		return nil, fmt.Errorf("unknown scorecard output format: %s", format)
+	}
+}
+
+// jsonEncoder writes the existing scapiv1alpha2.ScorecardOutput JSON
+// schema, unchanged from RunInternalPlugin's historical sole output.
+type jsonEncoder struct{}
+
+func (jsonEncoder) Encode(w io.Writer, output scapiv1alpha2.ScorecardOutput) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(output)
+}
+
+// writeOutputs writes output to config.OutputDir in each of
+// config.OutputFormats, in addition to RunInternalPlugin's returned
+// scapiv1alpha2.ScorecardOutput.
+func writeOutputs(output scapiv1alpha2.ScorecardOutput, config BasicAndOLMPluginConfig) error {
+	if len(config.OutputFormats) == 0 {
+		return nil
+	}
+	if config.OutputDir == "" {
+		return fmt.Errorf("OutputDir must be set when OutputFormats is non-empty")
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create scorecard output dir %s: %v", config.OutputDir, err)
+	}
+
+	for _, format := range config.OutputFormats {
+		enc, err := encoderFor(OutputFormat(format), config.CSVManifest)
+		if err != nil {
+			return err
+		}
+
+		ext := format
+		if ext == "" {
+			ext = string(OutputFormatJSON)
+		}
+		outPath := filepath.Join(config.OutputDir, fmt.Sprintf("scorecard-output.%s", ext))
+		f, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create scorecard output file %s: %v", outPath, err)
+		}
+		err = enc.Encode(f, output)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write scorecard output file %s: %v", outPath, err)
+		}
+	}
+
+	return nil
+}
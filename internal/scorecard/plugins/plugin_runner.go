@@ -26,6 +26,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/operator-framework/api/pkg/validation"
@@ -41,9 +42,11 @@ import (
 	"github.com/sirupsen/logrus"
 	v1 "k8s.io/api/core/v1"
 	extscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	cached "k8s.io/client-go/discovery/cached"
 	"k8s.io/client-go/kubernetes"
 	cgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -64,76 +67,202 @@ var (
 	dynamicDecoder runtime.Decoder
 	runtimeClient  client.Client
 	restMapper     *restmapper.DeferredDiscoveryRESTMapper
+)
+
+// clientMu serializes the code paths that assign kubeconfig/dynamicDecoder/
+// runtimeClient/restMapper (prepareRun without skipClientSetup, and
+// NewServer, both via setupRuntimeClient) against each other. Runs read
+// these globals without taking clientMu; Server relies on NewServer being
+// their only writer once it exists (see server.go).
+var clientMu sync.Mutex
+
+// runContext carries the state that is unique to a single test run against
+// a single CR: the operator deployment/proxy pod discovered for that run
+// and the cleanup functions registered while creating its resources. This
+// used to live in the package-level globals deploymentName, proxyPodGlobal
+// and cleanupFns, which made it unsafe to test more than one CR at a time.
+// Carrying it explicitly lets runTests be invoked from multiple goroutines,
+// one per CR, without the runs clobbering each other's state.
+type runContext struct {
 	deploymentName string
-	proxyPodGlobal *v1.Pod
+	proxyPod       *v1.Pod
 	cleanupFns     []cleanupFn
-)
+	logger         *runLogger
+}
 
 const (
 	scorecardContainerName = "scorecard-proxy"
 )
 
-var log *logrus.Logger
+// runLogger is the logger a single RunInternalPlugin/Server.RunSuite/
+// Server.StreamResults call logs through, built fresh for that run instead
+// of read off a package-level global. Before Server existed, a single
+// package-level log+logMu were shared for the life of the process; once
+// Server kept the runtime client warm across many calls, that meant two
+// callers racing on the same Server also raced on each other's log output
+// (one run's SetOutput/log lines could land in another run's logFile).
+// Building one runLogger per run and threading it explicitly removes that
+// shared state, so Server no longer needs to serialize runs against each
+// other - mu here only still guards a single run's own --parallel-crs
+// goroutines against each other, the same way logMu used to process-wide.
+type runLogger struct {
+	mu        sync.Mutex
+	log       *logrus.Logger
+	restoreTo io.Writer
+}
+
+// newRunLogger builds the logger for a single run, restoring output to out
+// whenever a CR's own capture buffer (see runTests) is done with it.
+func newRunLogger(out io.Writer) *runLogger {
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.TextFormatter{DisableColors: true})
+	logger.SetOutput(out)
+	return &runLogger{log: logger, restoreTo: out}
+}
+
+// Errorf and Warnf let callers log through a runLogger without reaching
+// into its log field directly, guarding against the same SetOutput race
+// runTests guards against when it swaps in a CR's capture buffer.
+func (rl *runLogger) Errorf(format string, args ...interface{}) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.log.Errorf(format, args...)
+}
+
+func (rl *runLogger) Warnf(format string, args ...interface{}) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.log.Warnf(format, args...)
+}
 
 func RunInternalPlugin(pluginType PluginType, config BasicAndOLMPluginConfig,
 	logFile io.Writer) (scapiv1alpha2.ScorecardOutput, error) {
 
 	// use stderr for logging not related to a single suite
-	log = logrus.New()
-	log.SetFormatter(&logrus.TextFormatter{DisableColors: true})
-	log.SetOutput(logFile)
+	logger := newRunLogger(logFile)
 
 	if err := validateScorecardPluginFlags(config, pluginType); err != nil {
 		return scapiv1alpha2.ScorecardOutput{}, err
 	}
-	defer func() {
-		if err := cleanupScorecard(); err != nil {
-			log.SetOutput(logFile)
-			log.Errorf("Failed to cleanup resources: (%v)", err)
-		}
-	}()
 
-	var tmpNamespaceVar string
-	var err error
-	kubeconfig, tmpNamespaceVar, err = k8sInternal.GetKubeconfigAndNamespace(config.Kubeconfig)
+	csv, deploymentName, proxyPod, cleanup, err := prepareRun(pluginType, &config, false, logger)
+	defer cleanup()
 	if err != nil {
-		return scapiv1alpha2.ScorecardOutput{}, fmt.Errorf("failed to build the kubeconfig: %v", err)
+		return scapiv1alpha2.ScorecardOutput{}, err
 	}
 
-	if config.Namespace == "" {
-		config.Namespace = tmpNamespaceVar
+	suites, err := runAllCRs(csv, pluginType, config, deploymentName, proxyPod, logger, nil)
+	if err != nil {
+		return scapiv1alpha2.ScorecardOutput{}, err
 	}
 
-	if err := setupRuntimeClient(); err != nil {
-		return scapiv1alpha2.ScorecardOutput{}, err
+	output := schelpers.TestSuitesToScorecardOutput(suites, "")
+	if err := writeOutputs(output, config); err != nil {
+		return output, err
+	}
+	return output, nil
+}
+
+// prepareRun resolves the kubeconfig/runtime client, CSV and CR manifests
+// that a run against config needs, mutating config's manifest-related
+// fields in place (matching what RunInternalPlugin did inline previously).
+// It's shared by RunInternalPlugin and Server's RunSuite/StreamResults so
+// both the one-shot and long-running entrypoints prepare a run the same
+// way. The returned cleanup func removes any temporary files it created and
+// must always be called, even on error.
+//
+// When skipClientSetup is set, prepareRun reuses the kubeconfig/runtime
+// client a caller has already warmed up (see Server in server.go) instead
+// of rebuilding them, and config.Namespace must already be set by that
+// caller.
+func prepareRun(pluginType PluginType, config *BasicAndOLMPluginConfig, skipClientSetup bool,
+	logger *runLogger) (
+	csv *olmapiv1alpha1.ClusterServiceVersion, deploymentName string, proxyPod *v1.Pod,
+	cleanup func(), err error) {
+
+	var cleanupFuncs []func()
+	cleanup = func() {
+		for _, fn := range cleanupFuncs {
+			fn()
+		}
 	}
 
-	csv := &olmapiv1alpha1.ClusterServiceVersion{}
-	if pluginType == OLMIntegration || config.OLMDeployed {
-		err := getCSV(config.CSVManifest, csv)
+	if !skipClientSetup {
+		clientMu.Lock()
+		var tmpNamespaceVar string
+		kubeconfig, tmpNamespaceVar, err = k8sInternal.GetKubeconfigAndNamespace(config.Kubeconfig)
 		if err != nil {
-			return scapiv1alpha2.ScorecardOutput{}, err
+			clientMu.Unlock()
+			return nil, "", nil, cleanup, fmt.Errorf("failed to build the kubeconfig: %v", err)
+		}
+
+		if config.Namespace == "" {
+			config.Namespace = tmpNamespaceVar
+		}
+
+		err = setupRuntimeClient()
+		clientMu.Unlock()
+		if err != nil {
+			return nil, "", nil, cleanup, err
+		}
+	}
+
+	// A bundle image packs a CSV, CRDs and metadata into a single OCI image
+	// rather than a deploy/ tree; extract it so the rest of this function
+	// can keep working purely off CSVManifest/CRDsDir as before.
+	if config.BundleImage != "" {
+		csvManifest, crdsDir, err := extractBundleImage(config.BundleImage, logger)
+		if err != nil {
+			return nil, "", nil, cleanup, fmt.Errorf("failed to extract bundle image %s: %v",
+				config.BundleImage, err)
+		}
+		config.CSVManifest = csvManifest
+		config.CRDsDir = crdsDir
+		cleanupFuncs = append(cleanupFuncs, func() {
+			if err := os.RemoveAll(filepath.Dir(crdsDir)); err != nil {
+				logger.Errorf("Could not delete extracted bundle image directory: (%v)", err)
+			}
+		})
+	}
+
+	csv = &olmapiv1alpha1.ClusterServiceVersion{}
+	if pluginType == OLMIntegration || config.OLMDeployed {
+		if err := getCSV(config.CSVManifest, csv, logger); err != nil {
+			return nil, "", nil, cleanup, err
 		}
 	}
 
 	// Extract operator manifests from the CSV if olm-deployed is set.
 	if config.OLMDeployed {
 		// Get deploymentName from the deployment manifest within the CSV.
-		var err error
 		deploymentName, err = getDeploymentName(csv.Spec.InstallStrategy)
 		if err != nil {
-			return scapiv1alpha2.ScorecardOutput{}, err
+			return nil, "", nil, cleanup, err
 		}
 		// Get the proxy pod, which should have been created with the CSV.
-		proxyPodGlobal, err = getPodFromDeployment(deploymentName, config.Namespace)
+		proxyPod, err = getPodFromDeployment(deploymentName, config.Namespace)
 		if err != nil {
-			return scapiv1alpha2.ScorecardOutput{}, err
+			return nil, "", nil, cleanup, err
 		}
 
-		config.CRManifest, err = getCRFromCSV(config.CRManifest, csv.ObjectMeta.Annotations["alm-examples"],
+		hadExplicitCRManifest := len(config.CRManifest) > 0
+		config.CRManifest, err = getCRsFromCSV(config.CRManifest, csv.ObjectMeta.Annotations["alm-examples"],
 			csv.GetName())
 		if err != nil {
-			return scapiv1alpha2.ScorecardOutput{}, err
+			return nil, "", nil, cleanup, err
+		}
+		// Temporary manifests created from alm-examples must outlive this
+		// function, since every CR is tested after prepareRun returns;
+		// remove them only once the whole run (all CRs) is done.
+		if !hadExplicitCRManifest {
+			crManifests := config.CRManifest
+			cleanupFuncs = append(cleanupFuncs, func() {
+				for _, f := range crManifests {
+					if err := os.Remove(f); err != nil {
+						logger.Errorf("Could not delete temporary CR manifest file: (%v)", err)
+					}
+				}
+			})
 		}
 
 	} else {
@@ -142,16 +271,14 @@ func RunInternalPlugin(pluginType PluginType, config BasicAndOLMPluginConfig,
 		if config.NamespacedManifest == "" {
 			file, err := yamlutil.GenerateCombinedNamespacedManifest(scaffold.DeployDir)
 			if err != nil {
-				return scapiv1alpha2.ScorecardOutput{}, err
+				return nil, "", nil, cleanup, err
 			}
 			config.NamespacedManifest = file.Name()
-			defer func() {
-				err := os.Remove(config.NamespacedManifest)
-				if err != nil {
-					log.Errorf("Could not delete temporary namespace manifest file: (%v)", err)
+			cleanupFuncs = append(cleanupFuncs, func() {
+				if err := os.Remove(config.NamespacedManifest); err != nil {
+					logger.Errorf("Could not delete temporary namespace manifest file: (%v)", err)
 				}
-				config.NamespacedManifest = ""
-			}()
+			})
 		}
 		// If no global manifest is given, combine all CRD's in the given CRD's dir.
 		if config.GlobalManifest == "" {
@@ -160,35 +287,104 @@ func RunInternalPlugin(pluginType PluginType, config BasicAndOLMPluginConfig,
 			}
 			gMan, err := yamlutil.GenerateCombinedGlobalManifest(config.CRDsDir)
 			if err != nil {
-				return scapiv1alpha2.ScorecardOutput{}, err
+				return nil, "", nil, cleanup, err
 			}
 			config.GlobalManifest = gMan.Name()
-			defer func() {
-				err := os.Remove(config.GlobalManifest)
-				if err != nil {
-					log.Errorf("Could not delete global manifest file: (%v)", err)
+			cleanupFuncs = append(cleanupFuncs, func() {
+				if err := os.Remove(config.GlobalManifest); err != nil {
+					logger.Errorf("Could not delete global manifest file: (%v)", err)
 				}
-				config.GlobalManifest = ""
-			}()
+			})
 		}
 	}
 
-	err = duplicateCRCheck(config.CRManifest)
-	if err != nil {
-		return scapiv1alpha2.ScorecardOutput{}, err
+	if err := duplicateCRCheck(config.CRManifest, logger); err != nil {
+		return nil, "", nil, cleanup, err
 	}
 
-	var suites []schelpers.TestSuite
-	for _, cr := range config.CRManifest {
-		crSuites, err := runTests(csv, pluginType, config, cr, logFile)
-		if err != nil {
-			return scapiv1alpha2.ScorecardOutput{}, err
+	return csv, deploymentName, proxyPod, cleanup, nil
+}
+
+// wrapCRErr annotates err with the CR manifest it came from, so a failure
+// surfaced by runAllCRs's sequential or parallel branch always identifies
+// which CR it belongs to.
+func wrapCRErr(cr string, err error) error {
+	return fmt.Errorf("cr %s: %v", cr, err)
+}
+
+// runAllCRs runs the pluginType test suite(s) against every CR in
+// config.CRManifest, one runContext per CR so concurrent runs don't share
+// mutable state. When config.ParallelCRs is set the CRs are tested
+// concurrently; otherwise they run one at a time, preserving prior
+// behavior. If onSuite is non-nil, it's additionally invoked for every
+// schelpers.TestSuite as soon as its CR finishes (possibly from a
+// goroutine under --parallel-crs), which Server.StreamResults uses to
+// stream results instead of waiting for every CR like the returned slice
+// does.
+func runAllCRs(csv *olmapiv1alpha1.ClusterServiceVersion, pluginType PluginType, config BasicAndOLMPluginConfig,
+	deploymentName string, proxyPod *v1.Pod, logger *runLogger,
+	onSuite func(schelpers.TestSuite)) ([]schelpers.TestSuite, error) {
+
+	if !config.ParallelCRs {
+		var suites []schelpers.TestSuite
+		for _, cr := range config.CRManifest {
+			sCtx := &runContext{deploymentName: deploymentName, proxyPod: proxyPod, logger: logger}
+			crSuites, err := runTests(csv, pluginType, config, cr, sCtx)
+			if err != nil {
+				return nil, wrapCRErr(cr, err)
+			}
+			suites = append(suites, crSuites...)
+			if onSuite != nil {
+				for _, suite := range crSuites {
+					onSuite(suite)
+				}
+			}
 		}
-		suites = append(suites, crSuites...)
+		return suites, nil
 	}
 
-	output := schelpers.TestSuitesToScorecardOutput(suites, "")
-	return output, nil
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		suites []schelpers.TestSuite
+		errs   []error
+	)
+	for _, cr := range config.CRManifest {
+		wg.Add(1)
+		go func(cr string) {
+			defer wg.Done()
+			sCtx := &runContext{deploymentName: deploymentName, proxyPod: proxyPod, logger: logger}
+			crSuites, err := runTests(csv, pluginType, config, cr, sCtx)
+			if err != nil {
+				func() {
+					mu.Lock()
+					defer mu.Unlock()
+					errs = append(errs, wrapCRErr(cr, err))
+				}()
+				return
+			}
+
+			func() {
+				mu.Lock()
+				defer mu.Unlock()
+				suites = append(suites, crSuites...)
+			}()
+
+			// Call onSuite outside mu so a slow consumer (e.g. a
+			// StreamResults client applying backpressure) only blocks this
+			// CR's goroutine, not every other CR's result bookkeeping.
+			if onSuite != nil {
+				for _, suite := range crSuites {
+					onSuite(suite)
+				}
+			}
+		}(cr)
+	}
+	wg.Wait()
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return suites, nil
 }
 
 func ListInternalPlugin(pluginType PluginType, config BasicAndOLMPluginConfig) (scapiv1alpha2.ScorecardOutput, error) {
@@ -266,7 +462,7 @@ func setupRuntimeClient() error {
 	return nil
 }
 
-func getCSV(csvManifest string, csv *olmapiv1alpha1.ClusterServiceVersion) error {
+func getCSV(csvManifest string, csv *olmapiv1alpha1.ClusterServiceVersion, logger *runLogger) error {
 	yamlSpec, err := ioutil.ReadFile(csvManifest)
 	if err != nil {
 		return fmt.Errorf("failed to read csv: %v", err)
@@ -286,7 +482,7 @@ func getCSV(csvManifest string, csv *olmapiv1alpha1.ClusterServiceVersion) error
 			return fmt.Errorf("error validating ClusterServiceVersion: %s", errorMsgs.String())
 		}
 		for _, w := range r.Warnings {
-			log.Warnf("CSV validation warning: type [%s] %s", w.Type, w.Detail)
+			logger.Warnf("CSV validation warning: type [%s] %s", w.Type, w.Detail)
 		}
 	}
 
@@ -305,66 +501,55 @@ func getDeploymentName(installStrategy olmapiv1alpha1.NamedInstallStrategy) (str
 	return stratDep.DeploymentSpecs[0].Name, nil
 }
 
-func getCRFromCSV(currentCRMans []string, crJSONStr string, csvName string) ([]string, error) {
-	finalCR := make([]string, 0)
-	logCRMsg := false
+// getCRsFromCSV returns the set of CR manifests to test. If currentCRMans is
+// non-empty it is returned as-is; otherwise every CR in the CSV's
+// metadata.annotations['alm-examples'] is written out to its own temporary
+// manifest file and all of them are returned, so the scorecard can run
+// against every example CR rather than just the first one.
+func getCRsFromCSV(currentCRMans []string, crJSONStr string, csvName string) ([]string, error) {
+	finalCRs := make([]string, 0)
 	if crMans := currentCRMans; len(crMans) == 0 {
-		// Create a temporary CR manifest from metadata if one is not provided.
+		// Create temporary CR manifests from metadata if none are provided.
 		if crJSONStr != "" {
 			var crs []interface{}
 			if err := json.Unmarshal([]byte(crJSONStr), &crs); err != nil {
-				return finalCR, fmt.Errorf("metadata.annotations['alm-examples'] in CSV %s"+
+				return finalCRs, fmt.Errorf("metadata.annotations['alm-examples'] in CSV %s"+
 					"incorrectly formatted: %v", csvName, err)
 			}
 			if len(crs) == 0 {
-				return finalCR, fmt.Errorf("no CRs found in metadata.annotations['alm-examples']"+
+				return finalCRs, fmt.Errorf("no CRs found in metadata.annotations['alm-examples']"+
 					" in CSV %s and cr-manifest config option not set", csvName)
 			}
-			// TODO: run scorecard against all CR's in CSV.
-			cr := crs[0]
-			logCRMsg = len(crs) > 1
-			crJSONBytes, err := json.Marshal(cr)
-			if err != nil {
-				return finalCR, err
-			}
-			crYAMLBytes, err := yaml.JSONToYAML(crJSONBytes)
-			if err != nil {
-				return finalCR, err
-			}
-			crFile, err := ioutil.TempFile("", "*.cr.yaml")
-			if err != nil {
-				return finalCR, err
-			}
-			if _, err := crFile.Write(crYAMLBytes); err != nil {
-				return finalCR, err
-			}
-			finalCR = []string{crFile.Name()}
-			defer func() {
-				for _, f := range finalCR {
-					if err := os.Remove(f); err != nil {
-						log.Errorf("Could not delete temporary CR manifest file: (%v)", err)
-					}
+			for _, cr := range crs {
+				crJSONBytes, err := json.Marshal(cr)
+				if err != nil {
+					return finalCRs, err
 				}
-			}()
+				crYAMLBytes, err := yaml.JSONToYAML(crJSONBytes)
+				if err != nil {
+					return finalCRs, err
+				}
+				crFile, err := ioutil.TempFile("", "*.cr.yaml")
+				if err != nil {
+					return finalCRs, err
+				}
+				if _, err := crFile.Write(crYAMLBytes); err != nil {
+					return finalCRs, err
+				}
+				finalCRs = append(finalCRs, crFile.Name())
+			}
 		} else {
-			return finalCR, errors.New(
+			return finalCRs, errors.New(
 				"cr-manifest config option must be set if CSV has no metadata.annotations['alm-examples']")
 		}
 	} else {
-		// TODO: run scorecard against all CR's in CSV.
-		finalCR = []string{crMans[0]}
-		logCRMsg = len(crMans) > 1
-	}
-	// Let users know that only the first CR is being tested.
-	if logCRMsg {
-		log.Infof("The scorecard does not support testing multiple CR's at once when run with --olm-deployed."+
-			" Testing the first CR %s", finalCR[0])
+		finalCRs = crMans
 	}
-	return finalCR, nil
+	return finalCRs, nil
 }
 
 // Check if there are duplicate CRs
-func duplicateCRCheck(crs []string) error {
+func duplicateCRCheck(crs []string, logger *runLogger) error {
 	gvks := []schema.GroupVersionKind{}
 	for _, cr := range crs {
 		file, err := ioutil.ReadFile(cr)
@@ -380,37 +565,76 @@ func duplicateCRCheck(crs []string) error {
 	dupMap := make(map[schema.GroupVersionKind]bool)
 	for _, gvk := range gvks {
 		if _, ok := dupMap[gvk]; ok {
-			log.Warnf("Duplicate gvks in CR list detected (%s); results may be inaccurate", gvk)
+			logger.Warnf("Duplicate gvks in CR list detected (%s); results may be inaccurate", gvk)
 		}
 		dupMap[gvk] = true
 	}
 	return nil
 }
 
+// runTests runs the pluginType test suite against a single CR, using sCtx
+// to track the operator deployment/proxy pod, cleanup functions and logger
+// for this run. sCtx.cleanupFns must not be shared between concurrent calls
+// to runTests, but sCtx.logger is - see runLogger.
 func runTests(csv *olmapiv1alpha1.ClusterServiceVersion, pluginType PluginType, config BasicAndOLMPluginConfig,
-	cr string, logFile io.Writer) ([]schelpers.TestSuite, error) {
+	cr string, sCtx *runContext) ([]schelpers.TestSuite, error) {
 	suites := make([]schelpers.TestSuite, 0)
+	rl := sCtx.logger
 
+	// Clean up whatever this run creates (deployment/RBAC/CR objects, and
+	// the namespace below) even if setup aborts partway through, not just
+	// on the happy path. sCtx.cleanupFns is local to this call, so this is
+	// safe to run concurrently with other in-flight runTests calls.
+	defer func() {
+		rl.mu.Lock()
+		rl.log.SetOutput(rl.restoreTo)
+		if err := cleanupScorecard(sCtx); err != nil {
+			rl.log.Errorf("Failed to cleanup resources: (%v)", err)
+		}
+		rl.mu.Unlock()
+	}()
+
+	// When testing CRs in parallel, give each CR's global/namespaced/CR
+	// resources a namespace of their own so that concurrent runs don't
+	// collide. This only applies when scorecard creates those resources
+	// itself (!OLMDeployed); under --olm-deployed the operator is already
+	// running and watching config.Namespace, so CRs must land there too.
+	namespace := config.Namespace
+	if config.ParallelCRs && !config.OLMDeployed {
+		crName := strings.TrimSuffix(filepath.Base(cr), filepath.Ext(cr))
+		namespace = fmt.Sprintf("%s-%s", config.Namespace, crName)
+		if err := createNamespace(namespace, sCtx); err != nil {
+			return suites, fmt.Errorf("failed to create namespace %s for parallel CR run: %v", namespace, err)
+		}
+	}
+
+	// rl is shared across concurrent runTests calls (one per CR under
+	// --parallel-crs), so repointing its output and writing to it must be
+	// atomic: otherwise one goroutine's SetOutput can land between
+	// another's SetOutput and Printf, and the log line ends up in the
+	// wrong CR's buffer.
 	logReadWriter := &bytes.Buffer{}
-	log.SetOutput(logReadWriter)
-	log.Printf("Running for cr: %s", cr)
+	rl.mu.Lock()
+	rl.log.SetOutput(logReadWriter)
+	rl.log.Printf("Running for cr: %s", cr)
+	rl.mu.Unlock()
 
 	if !config.OLMDeployed {
-		if err := createFromYAMLFile(config.Namespace, config.GlobalManifest, config.ProxyImage,
-			config.ProxyPullPolicy); err != nil {
+		if err := createFromYAMLFile(namespace, config.GlobalManifest, config.ProxyImage,
+			config.ProxyPullPolicy, sCtx); err != nil {
 			return suites, fmt.Errorf("failed to create global resources: %v", err)
 		}
-		if err := createFromYAMLFile(config.Namespace, config.NamespacedManifest, config.ProxyImage,
-			config.ProxyPullPolicy); err != nil {
+		if err := createFromYAMLFile(namespace, config.NamespacedManifest, config.ProxyImage,
+			config.ProxyPullPolicy, sCtx); err != nil {
 			return suites, fmt.Errorf("failed to create namespaced resources: %v", err)
 		}
 	}
 
-	if err := createFromYAMLFile(config.Namespace, cr, config.ProxyImage, config.ProxyPullPolicy); err != nil {
+	if err := createFromYAMLFile(namespace, cr, config.ProxyImage, config.ProxyPullPolicy, sCtx); err != nil {
 		return suites, fmt.Errorf("failed to create cr resource: %v", err)
 	}
 
-	obj, err := yamlToUnstructured(config.Namespace, cr)
+	obj, err := yamlToUnstructured(namespace, cr)
 	if err != nil {
 		return suites, fmt.Errorf("failed to decode custom resource manifest into object: %s", err)
 	}
@@ -424,7 +648,7 @@ func runTests(csv *olmapiv1alpha1.ClusterServiceVersion, pluginType PluginType,
 		conf := BasicTestConfig{
 			Client:   runtimeClient,
 			CR:       obj,
-			ProxyPod: proxyPodGlobal,
+			ProxyPod: sCtx.proxyPod,
 		}
 		basicTests := NewBasicTestSuite(conf)
 		basicTests.ApplySelector(config.Selector)
@@ -443,7 +667,7 @@ func runTests(csv *olmapiv1alpha1.ClusterServiceVersion, pluginType PluginType,
 			CR:       obj,
 			CSV:      csv,
 			CRDsDir:  config.CRDsDir,
-			ProxyPod: proxyPodGlobal,
+			ProxyPod: sCtx.proxyPod,
 			Bundle:   config.Bundle,
 		}
 		olmTests := NewOLMTestSuite(conf)
@@ -459,16 +683,40 @@ func runTests(csv *olmapiv1alpha1.ClusterServiceVersion, pluginType PluginType,
 		suites = append(suites, *olmTests)
 	}
 
-	// change logging back to main log
-	log.SetOutput(logFile)
-	// set up clean environment for every CR
-	if err := cleanupScorecard(); err != nil {
-		log.Errorf("Failed to cleanup resources: (%v)", err)
+	if config.TestsFile != "" {
+		declTests, err := NewDeclarativeTestSuite(DeclarativeTestConfig{
+			Client:    runtimeClient,
+			CR:        obj,
+			TestsFile: config.TestsFile,
+		})
+		if err != nil {
+			return suites, fmt.Errorf("failed to load declarative tests from %s: %v", config.TestsFile, err)
+		}
+		declTests.ApplySelector(config.Selector)
+
+		declTests.Run(context.TODO())
+		logs, err := ioutil.ReadAll(logReadWriter)
+		if err != nil {
+			declTests.Log = fmt.Sprintf("failed to read log buffer: %v", err)
+		} else {
+			declTests.Log = string(logs)
+		}
+		suites = append(suites, *declTests)
 	}
-	// reset cleanup functions
-	cleanupFns = []cleanupFn{}
-	// clear name of operator deployment
-	deploymentName = ""
 
 	return suites, nil
 }
+
+// createNamespace creates namespace in the cluster and registers its
+// deletion as a cleanup function on sCtx, so it's torn down alongside the
+// rest of this run's resources.
+func createNamespace(namespace string, sCtx *runContext) error {
+	ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+	if err := runtimeClient.Create(context.TODO(), ns); err != nil {
+		return err
+	}
+	sCtx.cleanupFns = append(sCtx.cleanupFns, func() error {
+		return runtimeClient.Delete(context.TODO(), ns)
+	})
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestGetCRsFromCSV(t *testing.T) {
+	cases := []struct {
+		name          string
+		currentCRMans []string
+		crJSONStr     string
+		wantCount     int
+		wantErr       bool
+	}{
+		{
+			name:          "explicit manifests are returned unchanged",
+			currentCRMans: []string{"deploy/crds/my-operator_v1_mykind_cr.yaml"},
+			crJSONStr:     `[{"apiVersion":"app.example.com/v1","kind":"MyKind"}]`,
+			wantCount:     1,
+		},
+		{
+			name:      "every alm-examples CR gets its own manifest",
+			crJSONStr: `[{"apiVersion":"app.example.com/v1","kind":"MyKind","metadata":{"name":"a"}},{"apiVersion":"app.example.com/v1","kind":"MyKind","metadata":{"name":"b"}}]`,
+			wantCount: 2,
+		},
+		{
+			name:      "empty alm-examples array is an error",
+			crJSONStr: `[]`,
+			wantErr:   true,
+		},
+		{
+			name:      "malformed alm-examples is an error",
+			crJSONStr: `not json`,
+			wantErr:   true,
+		},
+		{
+			name:    "no manifests and no alm-examples is an error",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			crs, err := getCRsFromCSV(tc.currentCRMans, tc.crJSONStr, "my-operator.v0.0.1")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got CRs: %v", crs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(crs) != tc.wantCount {
+				t.Fatalf("expected %d CR manifests, got %d: %v", tc.wantCount, len(crs), crs)
+			}
+			for i, want := range tc.currentCRMans {
+				if crs[i] != want {
+					t.Errorf("expected CR manifest %d to be %q, got %q", i, want, crs[i])
+				}
+			}
+
+			if tc.currentCRMans == nil {
+				for _, f := range crs {
+					defer os.Remove(f)
+					if _, err := ioutil.ReadFile(f); err != nil {
+						t.Errorf("expected temp CR manifest %s to be readable: %v", f, err)
+					}
+				}
+			}
+		})
+	}
+}
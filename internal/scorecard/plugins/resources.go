@@ -0,0 +1,190 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ghodss/yaml"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// cleanupFn removes a single resource (or undoes some other setup side
+// effect) created while preparing a run. createFromYAMLFile and
+// createNamespace each register one per object they create, so
+// cleanupScorecard can tear a run's resources back down once its CR has
+// been tested.
+type cleanupFn func() error
+
+// createFromYAMLFile creates every object in the YAML manifest at path in
+// namespace, registering each object's deletion on sCtx so cleanupScorecard
+// removes it once this CR's run finishes. Any Deployment the manifest
+// defines additionally gets the scorecard-proxy sidecar injected into its
+// pod template (using proxyImage/proxyPullPolicy) so the built-in test
+// suites can observe the operator's calls to the API server. path may be
+// empty, in which case createFromYAMLFile is a no-op.
+func createFromYAMLFile(namespace, path, proxyImage string, proxyPullPolicy v1.PullPolicy, sCtx *runContext) error {
+	if path == "" {
+		return nil
+	}
+
+	yamlBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	for _, doc := range bytes.Split(yamlBytes, []byte("\n---\n")) {
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return fmt.Errorf("failed to parse object in %s: %v", path, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+
+		if obj.GetKind() == "Deployment" && proxyImage != "" {
+			if err := injectProxyContainer(obj, proxyImage, proxyPullPolicy); err != nil {
+				return fmt.Errorf("failed to inject %s into %s: %v", scorecardContainerName, obj.GetName(), err)
+			}
+		}
+
+		if err := runtimeClient.Create(context.TODO(), obj); err != nil {
+			return fmt.Errorf("failed to create %s %s: %v", obj.GetKind(), obj.GetName(), err)
+		}
+		created := obj.DeepCopy()
+		sCtx.cleanupFns = append(sCtx.cleanupFns, func() error {
+			return runtimeClient.Delete(context.TODO(), created)
+		})
+	}
+
+	return nil
+}
+
+// injectProxyContainer appends the scorecard-proxy sidecar to obj's pod
+// template containers, so traffic from the operator it's deployed
+// alongside can be recorded by the built-in test suites.
+func injectProxyContainer(obj *unstructured.Unstructured, proxyImage string, proxyPullPolicy v1.PullPolicy) error {
+	containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	if err != nil {
+		return err
+	}
+
+	containers = append(containers, map[string]interface{}{
+		"name":            scorecardContainerName,
+		"image":           proxyImage,
+		"imagePullPolicy": string(proxyPullPolicy),
+	})
+	return unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
+// cleanupScorecard runs every cleanup function sCtx has accumulated, in
+// reverse order so resources are removed in the opposite order they were
+// created (e.g. objects inside a namespace before the namespace itself),
+// aggregating every error rather than stopping at the first one.
+func cleanupScorecard(sCtx *runContext) error {
+	var errs []error
+	for i := len(sCtx.cleanupFns) - 1; i >= 0; i-- {
+		if err := sCtx.cleanupFns[i](); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	sCtx.cleanupFns = nil
+
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	return nil
+}
+
+// yamlToUnstructured decodes the single-object YAML manifest at path,
+// defaulting its namespace to namespace if the manifest doesn't set one.
+func yamlToUnstructured(namespace, path string) (*unstructured.Unstructured, error) {
+	yamlBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := yaml.Unmarshal(yamlBytes, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	if obj.GetNamespace() == "" {
+		obj.SetNamespace(namespace)
+	}
+	return obj, nil
+}
+
+// waitUntilCRStatusExists polls obj until its status subresource is
+// populated or timeout elapses, so the test suites don't run against a CR
+// the operator hasn't reconciled yet.
+func waitUntilCRStatusExists(timeout time.Duration, obj *unstructured.Unstructured) error {
+	key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := runtimeClient.Get(context.TODO(), key, current); err != nil {
+			return false, nil
+		}
+		_, found, err := unstructured.NestedMap(current.Object, "status")
+		if err != nil {
+			return false, err
+		}
+		return found, nil
+	})
+}
+
+// getPodFromDeployment returns the first pod owned by the deployment
+// deploymentName in namespace. Under --olm-deployed this is the pod the
+// CSV's install strategy already deployed, with scorecard-proxy injected by
+// OLM rather than by createFromYAMLFile/injectProxyContainer above.
+func getPodFromDeployment(deploymentName, namespace string) (*v1.Pod, error) {
+	dep := &appsv1.Deployment{}
+	if err := runtimeClient.Get(context.TODO(),
+		client.ObjectKey{Namespace: namespace, Name: deploymentName}, dep); err != nil {
+		return nil, fmt.Errorf("failed to get deployment %s: %v", deploymentName, err)
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(dep.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse deployment %s selector: %v", deploymentName, err)
+	}
+
+	pods := &v1.PodList{}
+	if err := runtimeClient.List(context.TODO(), pods,
+		client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list pods for deployment %s: %v", deploymentName, err)
+	}
+	if len(pods.Items) == 0 {
+		return nil, fmt.Errorf("no pods found for deployment %s", deploymentName)
+	}
+	return &pods.Items[0], nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	schelpers "github.com/operator-framework/operator-sdk/internal/scorecard/helpers"
+)
+
+// restRequest is the REST equivalent of RunRequest: a plugin type and the
+// plugin config as inline JSON (rather than JSON-encoded-as-bytes, since a
+// REST body doesn't need the extra envelope a protobuf message does).
+type restRequest struct {
+	PluginType string                  `json:"pluginType"`
+	Config     BasicAndOLMPluginConfig `json:"config"`
+}
+
+// NewRESTHandler returns an http.Handler exposing the same ListSuites/
+// RunSuite/StreamResults operations as NewGRPCServer, for callers that
+// would rather speak plain HTTP/JSON than gRPC. Run output is logged to
+// logFile, same as the gRPC server; logFile is likewise wrapped in a
+// syncWriter since every request handled by this server shares it.
+func NewRESTHandler(s *Server, logFile io.Writer) http.Handler {
+	logFile = &syncWriter{w: logFile}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/listSuites", restHandler(s, logFile, restListSuites))
+	mux.HandleFunc("/v1/runSuite", restHandler(s, logFile, restRunSuite))
+	mux.HandleFunc("/v1/streamResults", restStreamResults(s, logFile))
+	return mux
+}
+
+func restHandler(s *Server, logFile io.Writer,
+	fn func(s *Server, logFile io.Writer, pluginType PluginType, config BasicAndOLMPluginConfig) (interface{}, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pluginType, config, err := decodeRESTRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		output, err := fn(s, logFile, pluginType, config)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(output); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
+
+func restListSuites(s *Server, logFile io.Writer, pluginType PluginType,
+	config BasicAndOLMPluginConfig) (interface{}, error) {
+	return s.ListSuites(pluginType, config)
+}
+
+func restRunSuite(s *Server, logFile io.Writer, pluginType PluginType,
+	config BasicAndOLMPluginConfig) (interface{}, error) {
+	return s.RunSuite(pluginType, config, logFile)
+}
+
+// restStreamResults streams one JSON-encoded schelpers.TestSuite per line
+// (newline-delimited JSON) as each CR finishes, flushing after every line
+// so callers can read results incrementally instead of buffering the
+// whole response.
+func restStreamResults(s *Server, logFile io.Writer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pluginType, config, err := decodeRESTRequest(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming not supported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		enc := json.NewEncoder(w)
+
+		results, errs := s.StreamResults(pluginType, config, logFile)
+		_ = DrainResults(results, errs,
+			func(suite schelpers.TestSuite) error {
+				if err := enc.Encode(suite); err != nil {
+					return err
+				}
+				flusher.Flush()
+				return nil
+			},
+			func(err error) error {
+				fmt.Fprintf(w, `{"error": %q}`+"\n", err.Error())
+				flusher.Flush()
+				return nil
+			},
+		)
+	}
+}
+
+func decodeRESTRequest(r *http.Request) (PluginType, BasicAndOLMPluginConfig, error) {
+	req := restRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return 0, req.Config, fmt.Errorf("failed to parse request body: %v", err)
+	}
+	switch req.PluginType {
+	case "basic", "":
+		return BasicOperator, req.Config, nil
+	case "olm":
+		return OLMIntegration, req.Config, nil
+	default:
+		return 0, req.Config, fmt.Errorf("unknown scorecard plugin type: %s", req.PluginType)
+	}
+}
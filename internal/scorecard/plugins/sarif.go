@@ -0,0 +1,150 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"encoding/json"
+	"io"
+
+	scapiv1alpha2 "github.com/operator-framework/operator-sdk/pkg/apis/scorecard/v1alpha2"
+)
+
+const sarifVersion = "2.1.0"
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+// sarifLog is the root of a SARIF 2.1.0 log file, consumable by GitHub code
+// scanning and other security dashboards.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifToolDriver `json:"driver"`
+}
+
+type sarifToolDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifEncoder maps a ScorecardOutput's failing/erroring tests onto SARIF
+// results, pointing locations at csvManifest since per-field line numbers
+// aren't tracked by the scorecard test results.
+type sarifEncoder struct {
+	csvManifest string
+}
+
+func (e sarifEncoder) Encode(w io.Writer, output scapiv1alpha2.ScorecardOutput) error {
+	uri := e.csvManifest
+	if uri == "" {
+		uri = "unknown"
+	}
+
+	var rules []sarifRule
+	var results []sarifResult
+	seenRules := map[string]bool{}
+
+	for _, suiteRes := range output.Results {
+		for _, t := range suiteRes.Tests {
+			if t.State != scapiv1alpha2.FailState && t.State != scapiv1alpha2.ErrorState {
+				continue
+			}
+
+			ruleID := suiteRes.Name + "/" + t.Name
+			if !seenRules[ruleID] {
+				rules = append(rules, sarifRule{ID: ruleID, Name: t.Name})
+				seenRules[ruleID] = true
+			}
+
+			level := "warning"
+			if t.State == scapiv1alpha2.ErrorState {
+				level = "error"
+			}
+
+			msg := t.Name
+			if len(t.Errors) > 0 {
+				msg = t.Errors[0]
+			}
+
+			results = append(results, sarifResult{
+				RuleID:  ruleID,
+				Level:   level,
+				Message: sarifMessage{Text: msg},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: uri},
+						Region:           sarifRegion{StartLine: 1},
+					},
+				}},
+			})
+		}
+	}
+
+	doc := sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifToolDriver{
+				Name:  "operator-sdk-scorecard",
+				Rules: rules,
+			}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	scapiv1alpha2 "github.com/operator-framework/operator-sdk/pkg/apis/scorecard/v1alpha2"
+)
+
+func TestSARIFEncoderEncode(t *testing.T) {
+	cases := []struct {
+		name        string
+		csvManifest string
+		output      scapiv1alpha2.ScorecardOutput
+		wantResults int
+		wantURI     string
+	}{
+		{
+			name:        "passing tests produce no results",
+			csvManifest: "deploy/olm-catalog/my-operator.clusterserviceversion.yaml",
+			output: scapiv1alpha2.ScorecardOutput{
+				Results: []scapiv1alpha2.ScorecardSuiteResult{
+					{
+						Name: "olm",
+						Tests: []scapiv1alpha2.ScorecardTestResult{
+							{Name: "bundle-validation", State: scapiv1alpha2.PassState},
+						},
+					},
+				},
+			},
+			wantResults: 0,
+		},
+		{
+			name:        "failing and erroring tests become results pointing at the CSV",
+			csvManifest: "deploy/olm-catalog/my-operator.clusterserviceversion.yaml",
+			output: scapiv1alpha2.ScorecardOutput{
+				Results: []scapiv1alpha2.ScorecardSuiteResult{
+					{
+						Name: "olm",
+						Tests: []scapiv1alpha2.ScorecardTestResult{
+							{Name: "bundle-validation", State: scapiv1alpha2.FailState, Errors: []string{"missing icon"}},
+							{Name: "crds-have-validation", State: scapiv1alpha2.ErrorState, Errors: []string{"crash"}},
+						},
+					},
+				},
+			},
+			wantResults: 2,
+			wantURI:     "deploy/olm-catalog/my-operator.clusterserviceversion.yaml",
+		},
+		{
+			name:        "empty csvManifest falls back to unknown",
+			csvManifest: "",
+			output: scapiv1alpha2.ScorecardOutput{
+				Results: []scapiv1alpha2.ScorecardSuiteResult{
+					{
+						Name: "olm",
+						Tests: []scapiv1alpha2.ScorecardTestResult{
+							{Name: "bundle-validation", State: scapiv1alpha2.FailState, Errors: []string{"missing icon"}},
+						},
+					},
+				},
+			},
+			wantResults: 1,
+			wantURI:     "unknown",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			enc := sarifEncoder{csvManifest: tc.csvManifest}
+			if err := enc.Encode(&buf, tc.output); err != nil {
+				t.Fatalf("Encode returned error: %v", err)
+			}
+
+			var doc sarifLog
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("Encode produced invalid JSON: %v", err)
+			}
+			if len(doc.Runs) != 1 {
+				t.Fatalf("expected exactly one run, got %d", len(doc.Runs))
+			}
+
+			results := doc.Runs[0].Results
+			if len(results) != tc.wantResults {
+				t.Errorf("expected %d results, got %d", tc.wantResults, len(results))
+			}
+			if tc.wantURI != "" {
+				for _, r := range results {
+					if r.Locations[0].PhysicalLocation.ArtifactLocation.URI != tc.wantURI {
+						t.Errorf("expected result location URI %q, got %q",
+							tc.wantURI, r.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	schelpers "github.com/operator-framework/operator-sdk/internal/scorecard/helpers"
+	k8sInternal "github.com/operator-framework/operator-sdk/internal/util/k8sutil"
+	scapiv1alpha2 "github.com/operator-framework/operator-sdk/pkg/apis/scorecard/v1alpha2"
+)
+
+// Server backs the `scorecard serve` subcommand: it resolves the
+// kubeconfig and runtime client once in NewServer and keeps them warm
+// across many ListSuites/RunSuite/StreamResults calls, rather than paying
+// that setup cost on every invocation the way the one-shot
+// RunInternalPlugin/ListInternalPlugin entrypoints do.
+//
+// RunSuite and StreamResults share the package-level kubeconfig/
+// runtimeClient/restMapper/dynamicDecoder globals (see plugin_runner.go),
+// but NewServer is the only thing that ever assigns them (RunSuite/
+// StreamResults pass skipClientSetup=true to prepareRun, so they only ever
+// read those globals, never write them), which makes concurrent reads safe
+// without a lock. The other thing runs used to share, the package-level log
+// var, is now a runLogger built fresh per call (see plugin_runner.go)
+// instead of a global, so two calls no longer race on each other's output -
+// as long as the logFile each call's runLogger writes to is itself safe for
+// concurrent writers. NewGRPCServer/NewRESTHandler reuse one logFile across
+// every request they serve, so they wrap it in a syncWriter before handing
+// it to RunSuite/StreamResults; callers with their own per-run logFile
+// don't need to. With that in place, Server genuinely runs ListSuites/
+// RunSuite/StreamResults concurrently for multiple callers rather than
+// serializing them.
+type Server struct {
+	kubeconfigPath string
+	namespace      string
+}
+
+// syncWriter serializes writes to w, so a logFile shared across many
+// concurrent RunSuite/StreamResults calls (see NewGRPCServer/NewRESTHandler)
+// doesn't interleave or race between them the way an unguarded io.Writer
+// would once Server stopped serializing those calls itself.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
+
+// NewServer resolves kubeconfigPath and builds the runtime client once,
+// returning a Server that reuses both for every subsequent call.
+func NewServer(kubeconfigPath string) (*Server, error) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	cfg, ns, err := k8sInternal.GetKubeconfigAndNamespace(kubeconfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build the kubeconfig: %v", err)
+	}
+	kubeconfig = cfg
+	if err := setupRuntimeClient(); err != nil {
+		return nil, err
+	}
+
+	return &Server{kubeconfigPath: kubeconfigPath, namespace: ns}, nil
+}
+
+// ListSuites implements the ListSuites RPC: it returns the suites and
+// tests available for pluginType without running them.
+func (s *Server) ListSuites(pluginType PluginType, config BasicAndOLMPluginConfig) (scapiv1alpha2.ScorecardOutput, error) {
+	return ListInternalPlugin(pluginType, config)
+}
+
+// RunSuite implements the RunSuite RPC: it runs config's test suite(s) to
+// completion and returns the final aggregated result.
+func (s *Server) RunSuite(pluginType PluginType, config BasicAndOLMPluginConfig,
+	logFile io.Writer) (scapiv1alpha2.ScorecardOutput, error) {
+	if err := validateScorecardPluginFlags(config, pluginType); err != nil {
+		return scapiv1alpha2.ScorecardOutput{}, err
+	}
+
+	logger := newRunLogger(logFile)
+	if config.Namespace == "" {
+		config.Namespace = s.namespace
+	}
+
+	csv, deploymentName, proxyPod, cleanup, err := prepareRun(pluginType, &config, true, logger)
+	defer cleanup()
+	if err != nil {
+		return scapiv1alpha2.ScorecardOutput{}, err
+	}
+
+	suites, err := runAllCRs(csv, pluginType, config, deploymentName, proxyPod, logger, nil)
+	if err != nil {
+		return scapiv1alpha2.ScorecardOutput{}, err
+	}
+	return schelpers.TestSuitesToScorecardOutput(suites, ""), nil
+}
+
+// StreamResults implements the StreamResults RPC: rather than waiting for
+// every CR to finish and returning one terminal ScorecardOutput, it emits
+// each CR's schelpers.TestSuite on the returned channel as soon as that CR
+// finishes, so a caller (a CI dashboard, an IDE integration) can show
+// structured progress events as a run proceeds. It shares runAllCRs with
+// RunSuite (via onSuite) so both get identical sequential/--parallel-crs
+// scheduling and error aggregation. The results channel is closed once
+// every CR has been tested; callers should also drain errCh, which
+// receives at most one error (an Aggregate under --parallel-crs, see
+// utilerrors.NewAggregate) and is closed alongside results.
+//
+// Like RunSuite, StreamResults builds its own runLogger and only reads the
+// package-level kubeconfig/runtimeClient/restMapper globals, so it runs
+// concurrently with other RunSuite/StreamResults calls against the same
+// Server rather than queuing behind them.
+func (s *Server) StreamResults(pluginType PluginType, config BasicAndOLMPluginConfig,
+	logFile io.Writer) (results <-chan schelpers.TestSuite, errs <-chan error) {
+
+	resultsCh := make(chan schelpers.TestSuite)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(resultsCh)
+		defer close(errCh)
+
+		if err := validateScorecardPluginFlags(config, pluginType); err != nil {
+			errCh <- err
+			return
+		}
+
+		logger := newRunLogger(logFile)
+		if config.Namespace == "" {
+			config.Namespace = s.namespace
+		}
+
+		csv, deploymentName, proxyPod, cleanup, err := prepareRun(pluginType, &config, true, logger)
+		defer cleanup()
+		if err != nil {
+			errCh <- err
+			return
+		}
+
+		if _, err := runAllCRs(csv, pluginType, config, deploymentName, proxyPod, logger,
+			func(suite schelpers.TestSuite) { resultsCh <- suite }); err != nil {
+			errCh <- err
+		}
+	}()
+
+	return resultsCh, errCh
+}
+
+// DrainResults reads results and errs, as returned by Server.StreamResults,
+// until both are closed: onResult is invoked for each test suite, and
+// onErr for any non-nil error. It's shared by the gRPC and REST
+// StreamResults handlers (see grpc.go/rest.go) so they don't each
+// reimplement the same channel-merge loop. Draining stops as soon as
+// either callback returns a non-nil error, which DrainResults then
+// returns.
+func DrainResults(results <-chan schelpers.TestSuite, errs <-chan error,
+	onResult func(schelpers.TestSuite) error, onErr func(error) error) error {
+	for results != nil || errs != nil {
+		select {
+		case suite, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			if err := onResult(suite); err != nil {
+				return err
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			if err == nil {
+				continue
+			}
+			if cbErr := onErr(err); cbErr != nil {
+				return cbErr
+			}
+		}
+	}
+	return nil
+}
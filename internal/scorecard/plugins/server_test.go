@@ -0,0 +1,93 @@
+// Copyright 2019 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scplugins
+
+import (
+	"errors"
+	"testing"
+
+	schelpers "github.com/operator-framework/operator-sdk/internal/scorecard/helpers"
+)
+
+func TestDrainResultsErrorPath(t *testing.T) {
+	cases := []struct {
+		name      string
+		results   []schelpers.TestSuite
+		errs      []error
+		onErrFail bool
+		wantErr   bool
+	}{
+		{
+			name:    "no error, results drained to completion",
+			results: []schelpers.TestSuite{{}, {}},
+		},
+		{
+			name:    "error on errs channel is passed to onErr",
+			results: []schelpers.TestSuite{{}},
+			errs:    []error{errors.New("boom")},
+		},
+		{
+			name:      "onErr's own error stops draining and is returned",
+			results:   []schelpers.TestSuite{{}},
+			errs:      []error{errors.New("boom")},
+			onErrFail: true,
+			wantErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			resultsCh := make(chan schelpers.TestSuite, len(c.results))
+			errCh := make(chan error, len(c.errs))
+			for _, r := range c.results {
+				resultsCh <- r
+			}
+			for _, e := range c.errs {
+				errCh <- e
+			}
+			close(resultsCh)
+			close(errCh)
+
+			var gotResults int
+			var gotErrs []error
+			err := DrainResults(resultsCh, errCh,
+				func(schelpers.TestSuite) error {
+					gotResults++
+					return nil
+				},
+				func(err error) error {
+					gotErrs = append(gotErrs, err)
+					if c.onErrFail {
+						return err
+					}
+					return nil
+				},
+			)
+
+			if (err != nil) != c.wantErr {
+				t.Fatalf("DrainResults() error = %v, wantErr %v", err, c.wantErr)
+			}
+			// DrainResults selects between results/errs non-deterministically, so
+			// once onErr itself fails and stops the drain early, some already
+			// buffered results may never reach onResult.
+			if !c.onErrFail && gotResults != len(c.results) {
+				t.Errorf("onResult called %d times, want %d", gotResults, len(c.results))
+			}
+			if len(gotErrs) != len(c.errs) {
+				t.Errorf("onErr called %d times, want %d", len(gotErrs), len(c.errs))
+			}
+		})
+	}
+}